@@ -2,9 +2,12 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"expvar"
 	"flag"
 	"fmt"
-	"log"
 	"net/http"
 	"os"
 	"os/signal"
@@ -12,15 +15,26 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc"
+
+	"github.com/ganizanisitara/open-moniker-svc/resolver-go/internal/auth"
 	"github.com/ganizanisitara/open-moniker-svc/resolver-go/internal/cache"
 	"github.com/ganizanisitara/open-moniker-svc/resolver-go/internal/catalog"
 	"github.com/ganizanisitara/open-moniker-svc/resolver-go/internal/config"
+	"github.com/ganizanisitara/open-moniker-svc/resolver-go/internal/egress"
+	"github.com/ganizanisitara/open-moniker-svc/resolver-go/internal/grpcapi"
 	"github.com/ganizanisitara/open-moniker-svc/resolver-go/internal/handlers"
+	"github.com/ganizanisitara/open-moniker-svc/resolver-go/internal/introspection"
+	"github.com/ganizanisitara/open-moniker-svc/resolver-go/internal/logging"
+	"github.com/ganizanisitara/open-moniker-svc/resolver-go/internal/middleware"
 	"github.com/ganizanisitara/open-moniker-svc/resolver-go/internal/service"
 	"github.com/ganizanisitara/open-moniker-svc/resolver-go/internal/telemetry"
 )
 
 func main() {
+	startedAt := time.Now()
+
 	// Parse command-line flags
 	configPath := flag.String("config", "../config.yaml", "Path to config file")
 	port := flag.Int("port", 0, "Port to listen on (overrides config)")
@@ -29,7 +43,8 @@ func main() {
 	// Load configuration
 	cfg, err := config.Load(*configPath)
 	if err != nil {
-		log.Fatalf("Failed to load config: %v", err)
+		hclog.Default().Error("failed to load config", "error", err)
+		os.Exit(1)
 	}
 
 	// Override port from flag if provided (0 means use config value)
@@ -39,17 +54,22 @@ func main() {
 		cfg.Server.Port = 8053 // Default fallback
 	}
 
+	if cfg.Server.GRPC.Enabled && cfg.Server.GRPC.Port == 0 {
+		cfg.Server.GRPC.Port = 8054 // Default fallback, one above the default HTTP port
+	}
+
 	// Set default project name if not configured
 	if cfg.ProjectName == "" {
 		cfg.ProjectName = "Open Moniker"
 	}
 
+	// Construct the one hclog.Logger for the process. It is passed to the
+	// catalog reloader, the auth chain, and the egress gateway below; the
+	// registry, cache, service, emitter, and handlers are built without it.
+	logger := logging.New(cfg.Logging)
+
 	// Display startup banner
-	log.Printf("==============================================")
-	log.Printf("  %s - Go Resolver", cfg.ProjectName)
-	log.Printf("  Port: %d", cfg.Server.Port)
-	log.Printf("  Catalog: %s", cfg.Catalog.DefinitionFile)
-	log.Printf("==============================================")
+	logger.Info("starting", "project", cfg.ProjectName, "port", cfg.Server.Port, "catalog", cfg.Catalog.DefinitionFile)
 
 	// Initialize components
 	registry := catalog.NewRegistry()
@@ -72,31 +92,99 @@ func main() {
 
 	nodes, err := catalog.LoadCatalog(catalogPath)
 	if err != nil {
-		log.Printf("Warning: Failed to load catalog: %v - running with empty catalog", err)
+		logger.Warn("failed to load catalog, running with empty catalog", "error", err)
 	} else {
 		registry.RegisterMany(nodes)
-		log.Printf("Loaded %d catalog nodes", len(nodes))
+		logger.Info("loaded catalog", "nodes", len(nodes))
+	}
+
+	// Hot-reload the catalog on filesystem change or the configured interval,
+	// whichever comes first, diffing against the registry and applying
+	// add/update/remove atomically. ReloadIntervalSeconds of zero is a valid
+	// configuration (fs-watch-only, per catalog.NewReloader's doc comment) -
+	// reload_enabled is what gates whether a reloader runs at all.
+	var reloader *catalog.Reloader
+	if cfg.Catalog.ReloadEnabled {
+		interval := time.Duration(cfg.Catalog.ReloadIntervalSeconds) * time.Second
+		reloader, err = catalog.NewReloader(catalogPath, interval, registry, cacheInst, logger)
+		if err != nil {
+			logger.Warn("failed to start catalog reloader", "error", err)
+		} else {
+			go reloader.Start()
+			defer reloader.Stop()
+		}
 	}
 
 	// Initialize telemetry
 	emitter, err := telemetry.NewFromConfig(&cfg.Telemetry)
 	if err != nil {
-		log.Printf("Warning: Failed to initialize telemetry: %v", err)
+		logger.Warn("failed to initialize telemetry", "error", err)
 		emitter = telemetry.NewNoOpEmitter()
 	}
 	defer emitter.Stop()
 
 	if cfg.Telemetry.Enabled {
-		log.Printf("Telemetry enabled: sink=%s, batch_size=%d, flush_interval=%.3fs",
-			cfg.Telemetry.SinkType, cfg.Telemetry.BatchSize, cfg.Telemetry.FlushIntervalSeconds)
+		logger.Info("telemetry enabled",
+			"sink", cfg.Telemetry.SinkType,
+			"batch_size", cfg.Telemetry.BatchSize,
+			"flush_interval", cfg.Telemetry.FlushIntervalSeconds)
 	}
 
 	// Create service
 	svc := service.NewMonikerService(registry, cacheInst, cfg, emitter)
 
+	// Build the authentication chain in the order given by cfg.Auth.MethodOrder.
+	// Each request is authenticated by the first method that produces
+	// credentials; when Enforce is false the request proceeds unauthenticated
+	// but the resolved principal (if any) is still attached for telemetry.
+	var authChain *auth.Chain
+	mtlsEnabled := false
+	if cfg.Auth.Enabled {
+		available := map[string]auth.Authenticator{}
+
+		if bearer, err := auth.NewBearer(cfg.Auth.Bearer); err != nil {
+			logger.Warn("failed to configure bearer auth", "error", err)
+		} else {
+			available["bearer"] = bearer
+		}
+
+		if cfg.Auth.Basic.FilePath != "" {
+			if basic, err := auth.NewBasic(cfg.Auth.Basic); err != nil {
+				logger.Warn("failed to configure basic auth", "error", err)
+			} else {
+				available["basic"] = basic
+			}
+		}
+
+		if len(cfg.Auth.MTLS.Allowed) > 0 {
+			available["mtls"] = auth.NewMTLS(cfg.Auth.MTLS)
+			mtlsEnabled = true
+		}
+
+		authChain = auth.NewChain(cfg.Auth.MethodOrder, available, cfg.Auth.Enforce, logger)
+	}
+	adminScope := cfg.Auth.AdminScope
+	if adminScope == "" {
+		adminScope = "admin"
+	}
+	requireAdmin := func(h http.Handler) http.Handler {
+		if cfg.Auth.Enabled {
+			return auth.RequireScope(adminScope, h)
+		}
+		return h
+	}
+
+	// Publish live resolver internals for /debug/vars and /metrics, computed
+	// at scrape time from cacheInst, registry, and emitter.
+	counters := introspection.NewCounters()
+	introspection.Register(counters, registry, cacheInst, emitter, startedAt)
+
 	// Set up HTTP routes
 	mux := http.NewServeMux()
 
+	mux.Handle("/debug/vars", expvar.Handler())
+	mux.Handle("/metrics", introspection.PrometheusHandler(counters, registry, cacheInst, emitter, startedAt))
+
 	// Health check endpoint
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -148,14 +236,20 @@ func main() {
 	metadataHandler := handlers.NewMetadataHandler(svc, registry)
 	treeHandler := handlers.NewTreeHandler(registry)
 
-	// Admin endpoints
-	updateStatusHandler := handlers.NewUpdateStatusHandler(registry)
+	// Admin endpoints - updateStatusHandler, fetchHandler, and
+	// refreshCacheHandler additionally require the configured admin scope
+	// (auth.admin_scope, defaulting to "admin").
+	updateStatusHandler := requireAdmin(handlers.NewUpdateStatusHandler(registry))
 	auditHandler := handlers.NewAuditLogHandler(registry)
-	fetchHandler := handlers.NewFetchDataHandler(registry)
+	// FetchDataHandler (and any other resolver step reaching an external
+	// system) is routed through the egress gateway, which rejects
+	// non-allowlisted hosts and applies the per-host timeout/retry/TLS pin.
+	egressGateway := egress.NewGateway(cfg.Egress, logger)
+	fetchHandler := requireAdmin(handlers.NewFetchDataHandler(registry, egressGateway))
 
 	// Cache endpoints
 	cacheStatusHandler := handlers.NewCacheStatusHandler()
-	refreshCacheHandler := handlers.NewRefreshCacheHandler(registry)
+	refreshCacheHandler := requireAdmin(handlers.NewRefreshCacheHandler(registry))
 
 	// Telemetry endpoints
 	telemetryHandler := handlers.NewTelemetryAccessHandler()
@@ -163,19 +257,62 @@ func main() {
 	// UI endpoint
 	uiHandler := handlers.NewUIHandler()
 
-	// Register all routes
-	mux.Handle("/resolve/", resolveHandler)
-	mux.Handle("/describe/", describeHandler)
-	mux.Handle("/list/", listHandler)
-	mux.Handle("/lineage/", lineageHandler)
+	// Rate limiting and circuit breaking, configured per route prefix under
+	// the resilience: block. A route with no entry in cfg.Resilience passes
+	// through unwrapped.
+	resilienceHooks := middleware.Hooks{
+		OnRateLimited: func(route string) { emitter.IncrCounter("resilience.rate_limited", route) },
+		OnBreakerOpen: func(route string) { emitter.IncrCounter("resilience.breaker_open", route) },
+	}
+	wrap := func(routePrefix string, h http.Handler) http.Handler {
+		return middleware.Wrap(cfg.Resilience, routePrefix, resilienceHooks, h)
+	}
+
+	// withMetrics records the handler's latency and, for resolve, increments
+	// resolves_total/resolves_by_prefix - the sites introspection.Counters
+	// reads from at scrape time.
+	withMetrics := func(handlerName string, h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			h.ServeHTTP(w, r)
+			counters.ObserveLatency(handlerName, time.Since(start).Seconds())
+			if handlerName == "resolve" {
+				counters.IncrResolve(strings.TrimPrefix(r.URL.Path, "/resolve/"))
+			}
+		})
+	}
+
+	// Register all routes. Every route passes through wrap(prefix, h), not
+	// just the ones historically thought to need a concurrency cap - Wrap
+	// already no-ops when cfg.Resilience has no entry for that prefix, so
+	// there's no reason a resilience: entry for e.g. /list/ should be a no-op
+	// no matter what an operator puts in config. Every route is also wrapped
+	// in withMetrics so resolver_handler_latency_seconds gets samples for all
+	// of them, not just resolve.
+	mux.Handle("/resolve/", withMetrics("resolve", wrap("/resolve/", resolveHandler)))
+	mux.Handle("/describe/", withMetrics("describe", wrap("/describe/", describeHandler)))
+	mux.Handle("/list/", withMetrics("list", wrap("/list/", listHandler)))
+	mux.Handle("/lineage/", withMetrics("lineage", wrap("/lineage/", lineageHandler)))
 
 	// Catalog routes
-	mux.Handle("/catalog/search", searchHandler)
-	mux.Handle("/catalog/stats", statsHandler)
-	mux.HandleFunc("/catalog", func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle("/catalog/search", withMetrics("catalog_search", wrap("/catalog/search", searchHandler)))
+	mux.Handle("/catalog/stats", withMetrics("catalog_stats", wrap("/catalog/stats", statsHandler)))
+	mux.Handle("/catalog/reload", withMetrics("catalog_reload", wrap("/catalog/reload", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if reloader == nil {
+			http.Error(w, "catalog reload is not configured (set catalog.reload_enabled)", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(reloader.Reload())
+	}))))
+	mux.Handle("/catalog", withMetrics("catalog", wrap("/catalog", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		catalogListHandler.ServeHTTP(w, r)
-	})
-	mux.HandleFunc("/catalog/", func(w http.ResponseWriter, r *http.Request) {
+	}))))
+	mux.Handle("/catalog/", withMetrics("catalog", wrap("/catalog/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Route to specific handlers based on path
 		path := r.URL.Path
 		if strings.HasSuffix(path, "/status") && r.Method == "PUT" {
@@ -185,63 +322,137 @@ func main() {
 		} else {
 			catalogListHandler.ServeHTTP(w, r)
 		}
-	})
+	}))))
 
-	// Batch resolve
-	mux.Handle("/resolve/batch", batchHandler)
+	// Batch resolve - also needs the concurrency cap since it fans out to
+	// multiple resolves.
+	mux.Handle("/resolve/batch", withMetrics("resolve_batch", wrap("/resolve/batch", batchHandler)))
 
 	// Metadata and tree
-	mux.Handle("/metadata/", metadataHandler)
-	mux.Handle("/tree/", treeHandler)
-	mux.HandleFunc("/tree", func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle("/metadata/", withMetrics("metadata", wrap("/metadata/", metadataHandler)))
+	mux.Handle("/tree/", withMetrics("tree", wrap("/tree/", treeHandler)))
+	mux.Handle("/tree", withMetrics("tree", wrap("/tree", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		treeHandler.ServeHTTP(w, r)
-	})
+	}))))
 
-	// Fetch data
-	mux.Handle("/fetch/", fetchHandler)
+	// Fetch data - hits external systems, so it gets the concurrency cap too
+	mux.Handle("/fetch/", withMetrics("fetch", wrap("/fetch/", fetchHandler)))
 
 	// Cache
-	mux.Handle("/cache/status", cacheStatusHandler)
-	mux.Handle("/cache/refresh/", refreshCacheHandler)
+	mux.Handle("/cache/status", withMetrics("cache_status", wrap("/cache/status", cacheStatusHandler)))
+	mux.Handle("/cache/refresh/", withMetrics("cache_refresh", wrap("/cache/refresh/", refreshCacheHandler)))
 
 	// Telemetry
-	mux.Handle("/telemetry/access", telemetryHandler)
+	mux.Handle("/telemetry/access", withMetrics("telemetry_access", wrap("/telemetry/access", telemetryHandler)))
 
 	// UI
-	mux.Handle("/ui", uiHandler)
+	mux.Handle("/ui", withMetrics("ui", wrap("/ui", uiHandler)))
 
 	// Create server
+	var rootHandler http.Handler = mux
+	if authChain != nil {
+		rootHandler = authChain.Middleware(rootHandler)
+	}
+	rootHandler = logging.Middleware(logger, rootHandler)
+
 	addr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
 	server := &http.Server{
 		Addr:         addr,
-		Handler:      mux,
+		Handler:      rootHandler,
 		ReadTimeout:  30 * time.Second,
 		WriteTimeout: 30 * time.Second,
 		IdleTimeout:  120 * time.Second,
 	}
 
+	// The "mtls" auth method reads r.TLS.PeerCertificates, which is only ever
+	// populated if this listener itself terminates TLS and requests/verifies
+	// a client certificate - so serving it requires both a server cert/key
+	// and the CA bundle client certs are verified against.
+	if mtlsEnabled {
+		if cfg.Server.TLS.CertFile == "" || cfg.Server.TLS.KeyFile == "" {
+			logger.Error("auth.mtls.allowed is configured but server.tls.cert_file/key_file are not; mtls can never match over plain HTTP")
+			os.Exit(1)
+		}
+
+		caPEM, err := os.ReadFile(cfg.Auth.MTLS.ClientCAFile)
+		if err != nil {
+			logger.Error("failed to read auth.mtls.client_ca_file", "error", err)
+			os.Exit(1)
+		}
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(caPEM) {
+			logger.Error("no certificates found in auth.mtls.client_ca_file", "path", cfg.Auth.MTLS.ClientCAFile)
+			os.Exit(1)
+		}
+
+		server.TLSConfig = &tls.Config{
+			ClientAuth: tls.RequireAndVerifyClientCert,
+			ClientCAs:  clientCAs,
+		}
+	}
+
 	// Start server in a goroutine
 	go func() {
-		log.Printf("Starting Go resolver on %s", addr)
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Server error: %v", err)
+		logger.Info("starting HTTP resolver", "addr", addr, "tls", server.TLSConfig != nil)
+		var err error
+		if server.TLSConfig != nil {
+			err = server.ListenAndServeTLS(cfg.Server.TLS.CertFile, cfg.Server.TLS.KeyFile)
+		} else {
+			err = server.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
+			logger.Error("server error", "error", err)
+			os.Exit(1)
 		}
 	}()
 
+	// Start the gRPC server alongside HTTP, sharing the same service instance
+	// so cache, telemetry, and registry state stay unified across transports.
+	var grpcServer *grpc.Server
+	if cfg.Server.GRPC.Enabled {
+		grpcAddr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.GRPC.Port)
+		grpcServer, err = grpcapi.Listen(grpcAddr, grpcapi.NewServer(svc, registry), authChain, cfg.Server.GRPC.Reflection)
+		if err != nil {
+			logger.Error("failed to start gRPC server", "error", err)
+			os.Exit(1)
+		}
+		logger.Info("starting gRPC resolver", "addr", grpcAddr)
+	}
+
 	// Wait for interrupt signal for graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	log.Println("Shutting down server...")
+	logger.Info("shutting down server")
 
 	// Graceful shutdown with 30s timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
 	if err := server.Shutdown(ctx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+		logger.Error("server forced to shutdown", "error", err)
+		os.Exit(1)
+	}
+
+	if grpcServer != nil {
+		// GracefulStop has no deadline of its own and waits for every
+		// in-flight RPC to finish, so a long-lived call or a slow client can
+		// hang shutdown indefinitely even though the HTTP half above honors
+		// its 30s context. Give it the same budget, then fall back to a hard
+		// Stop.
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			logger.Warn("grpc graceful stop timed out, forcing shutdown")
+			grpcServer.Stop()
+		}
 	}
 
-	log.Println("Server stopped")
+	logger.Info("server stopped")
 }
@@ -0,0 +1,162 @@
+// Package auth implements the pluggable authentication chain driven by
+// config.AuthConfig.MethodOrder: bearer tokens/JWT, htpasswd-style basic
+// auth, and mTLS client certificates.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// ErrAuthFailed indicates a configured method found credentials but rejected
+// them (e.g. bad password, invalid JWT). The underlying detail is already
+// logged server-side by Chain.Authenticate; callers should map this to a
+// generic 403/codes.PermissionDenied rather than repeating it to the caller.
+var ErrAuthFailed = errors.New("authentication failed")
+
+// ErrAuthRequired indicates enforcement is on and no configured method
+// matched the request.
+var ErrAuthRequired = errors.New("authentication required")
+
+// Principal is the authenticated identity attached to the request context,
+// regardless of which method produced it.
+type Principal struct {
+	Subject string
+	Scopes  []string
+	Method  string
+}
+
+// HasScope reports whether the principal was granted scope.
+func (p *Principal) HasScope(scope string) bool {
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Authenticator extracts a Principal from a request, or reports that it
+// found no credentials for its method (not an error - the chain tries the
+// next method) or that the credentials it found were invalid (an error -
+// the chain stops and the request is rejected).
+type Authenticator interface {
+	// Name identifies the method, e.g. "bearer", "basic", "mtls".
+	Name() string
+	// Authenticate returns (principal, true, nil) on success, (nil, false,
+	// nil) when the request carries no credentials for this method, and
+	// (nil, false, err) when credentials were present but invalid.
+	Authenticate(r *http.Request) (*Principal, bool, error)
+}
+
+type ctxKey string
+
+const principalCtxKey ctxKey = "auth.principal"
+
+// FromContext returns the principal attached by Middleware (or by the gRPC
+// unary interceptor), if any.
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalCtxKey).(*Principal)
+	return p, ok
+}
+
+// ContextWithPrincipal attaches p to ctx the same way Middleware does, so
+// other transports (the gRPC unary interceptor) can make FromContext work
+// identically regardless of which transport authenticated the request.
+func ContextWithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalCtxKey, p)
+}
+
+// Chain tries each configured Authenticator in order, attaching the first
+// principal found. A request that no method matches proceeds (with no
+// principal in context) when enforce is false and is rejected with 401 when
+// enforce is true. An authenticator returning an error for the method it
+// owns yields 403 whether or not enforce is set.
+type Chain struct {
+	methods []Authenticator
+	enforce bool
+	logger  hclog.Logger
+}
+
+// NewChain builds a Chain from methods in MethodOrder, skipping any name with
+// no corresponding entry in available (e.g. a method mentioned in config but
+// not registered).
+func NewChain(order []string, available map[string]Authenticator, enforce bool, logger hclog.Logger) *Chain {
+	c := &Chain{enforce: enforce, logger: logger.Named("auth")}
+	for _, name := range order {
+		if a, ok := available[name]; ok {
+			c.methods = append(c.methods, a)
+		}
+	}
+	return c
+}
+
+// Authenticate runs the chain's methods in order against r and returns the
+// first matching Principal (nil if none matched and enforcement is off).
+// This is the transport-agnostic core that both Middleware (HTTP) and the
+// gRPC unary interceptor drive, so enabling server.grpc.enabled can't bypass
+// the same auth.enabled/auth.enforce policy HTTP requests go through.
+func (c *Chain) Authenticate(r *http.Request) (*Principal, error) {
+	for _, method := range c.methods {
+		principal, matched, err := method.Authenticate(r)
+		if err != nil {
+			// The underlying error (e.g. "unknown user" vs "bad password", a
+			// raw JWT parse failure) is never returned to the caller - that
+			// would leak enumeration/validation detail to whoever is probing
+			// auth. Log it server-side and return a generic failure instead.
+			c.logger.Warn("auth failed", "method", method.Name(), "error", err)
+			return nil, ErrAuthFailed
+		}
+		if matched {
+			return principal, nil
+		}
+	}
+
+	if c.enforce {
+		return nil, ErrAuthRequired
+	}
+
+	return nil, nil
+}
+
+// Middleware authenticates each request with the first method in the chain
+// that produces credentials, attaches the resulting Principal to the request
+// context, and enforces the configured policy.
+func (c *Chain) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, err := c.Authenticate(r)
+		switch {
+		case errors.Is(err, ErrAuthFailed):
+			http.Error(w, "authentication failed", http.StatusForbidden)
+			return
+		case errors.Is(err, ErrAuthRequired):
+			http.Error(w, "authentication required", http.StatusUnauthorized)
+			return
+		}
+
+		ctx := r.Context()
+		if principal != nil {
+			ctx = ContextWithPrincipal(ctx, principal)
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequireScope wraps next so it only runs if the request's principal has
+// scope; used by admin endpoints like updateStatusHandler, fetchHandler, and
+// refreshCacheHandler. When auth is not enforced, a missing principal is
+// treated as not having the scope.
+func RequireScope(scope string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := FromContext(r.Context())
+		if !ok || !principal.HasScope(scope) {
+			http.Error(w, fmt.Sprintf("requires %q scope", scope), http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// stubMethod is a minimal Authenticator for exercising Chain's ordering and
+// enforcement logic without any real credential parsing.
+type stubMethod struct {
+	name      string
+	principal *Principal
+	matched   bool
+	err       error
+}
+
+func (s *stubMethod) Name() string { return s.name }
+
+func (s *stubMethod) Authenticate(r *http.Request) (*Principal, bool, error) {
+	return s.principal, s.matched, s.err
+}
+
+func TestChainTriesMethodsInOrderAndStopsAtFirstMatch(t *testing.T) {
+	first := &stubMethod{name: "bearer", matched: false}
+	second := &stubMethod{name: "basic", principal: &Principal{Subject: "alice", Method: "basic"}, matched: true}
+	third := &stubMethod{name: "mtls", principal: &Principal{Subject: "should-not-be-reached"}, matched: true}
+
+	chain := NewChain([]string{"bearer", "basic", "mtls"},
+		map[string]Authenticator{"bearer": first, "basic": second, "mtls": third},
+		false, hclog.NewNullLogger())
+
+	principal, err := chain.Authenticate(httptestRequest())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if principal == nil || principal.Subject != "alice" {
+		t.Fatalf("expected alice from the second method, got %+v", principal)
+	}
+}
+
+func TestChainUnenforcedAllowsNoMatch(t *testing.T) {
+	chain := NewChain([]string{"bearer"},
+		map[string]Authenticator{"bearer": &stubMethod{name: "bearer", matched: false}},
+		false, hclog.NewNullLogger())
+
+	principal, err := chain.Authenticate(httptestRequest())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if principal != nil {
+		t.Fatalf("expected no principal, got %+v", principal)
+	}
+}
+
+func TestChainEnforcedRejectsNoMatch(t *testing.T) {
+	chain := NewChain([]string{"bearer"},
+		map[string]Authenticator{"bearer": &stubMethod{name: "bearer", matched: false}},
+		true, hclog.NewNullLogger())
+
+	_, err := chain.Authenticate(httptestRequest())
+	if !errors.Is(err, ErrAuthRequired) {
+		t.Fatalf("expected ErrAuthRequired, got %v", err)
+	}
+}
+
+func TestChainMethodErrorYieldsAuthFailed(t *testing.T) {
+	chain := NewChain([]string{"bearer"},
+		map[string]Authenticator{"bearer": &stubMethod{name: "bearer", err: errors.New("bad token")}},
+		false, hclog.NewNullLogger())
+
+	_, err := chain.Authenticate(httptestRequest())
+	if !errors.Is(err, ErrAuthFailed) {
+		t.Fatalf("expected ErrAuthFailed, got %v", err)
+	}
+}
+
+func TestPrincipalHasScope(t *testing.T) {
+	p := &Principal{Scopes: []string{"read", "admin"}}
+
+	if !p.HasScope("admin") {
+		t.Fatal("expected HasScope(\"admin\") to be true")
+	}
+	if p.HasScope("write") {
+		t.Fatal("expected HasScope(\"write\") to be false")
+	}
+}
+
+func httptestRequest() *http.Request {
+	r, _ := http.NewRequest(http.MethodGet, "/resolve/foo", nil)
+	return r
+}
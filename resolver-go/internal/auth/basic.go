@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"golang.org/x/crypto/bcrypt"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ganizanisitara/open-moniker-svc/resolver-go/internal/config"
+)
+
+type basicEntry struct {
+	PasswordHash string   `yaml:"password_hash"`
+	Scopes       []string `yaml:"scopes"`
+}
+
+// Basic authenticates "Authorization: Basic <base64>" requests against an
+// htpasswd-style file of username -> bcrypt hash + scopes.
+type Basic struct {
+	users map[string]basicEntry
+}
+
+// NewBasic loads the htpasswd-style file at cfg.FilePath.
+func NewBasic(cfg config.BasicConfig) (*Basic, error) {
+	data, err := os.ReadFile(cfg.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("read basic auth file: %w", err)
+	}
+
+	var users map[string]basicEntry
+	if err := yaml.Unmarshal(data, &users); err != nil {
+		return nil, fmt.Errorf("parse basic auth file: %w", err)
+	}
+
+	return &Basic{users: users}, nil
+}
+
+func (b *Basic) Name() string { return "basic" }
+
+func (b *Basic) Authenticate(r *http.Request) (*Principal, bool, error) {
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		return nil, false, nil
+	}
+
+	entry, ok := b.users[username]
+	if !ok {
+		return nil, false, fmt.Errorf("unknown user %q", username)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(entry.PasswordHash), []byte(password)); err != nil {
+		return nil, false, fmt.Errorf("bad password for %q", username)
+	}
+
+	return &Principal{Subject: username, Scopes: entry.Scopes, Method: "basic"}, true, nil
+}
@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/MicahParks/keyfunc/v2"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/ganizanisitara/open-moniker-svc/resolver-go/internal/config"
+)
+
+// Bearer authenticates "Authorization: Bearer <token>" requests, either
+// against a static token table or a JWKS-verified JWT.
+type Bearer struct {
+	cfg  config.BearerConfig
+	jwks *keyfunc.JWKS
+}
+
+// NewBearer constructs a Bearer authenticator. If cfg.JWKSURL is set, it
+// fetches the JWKS used to verify JWTs once, here; the key set is not
+// refreshed afterwards.
+func NewBearer(cfg config.BearerConfig) (*Bearer, error) {
+	b := &Bearer{cfg: cfg}
+
+	if cfg.JWKSURL != "" {
+		jwks, err := keyfunc.Get(cfg.JWKSURL, keyfunc.Options{})
+		if err != nil {
+			return nil, fmt.Errorf("fetch jwks: %w", err)
+		}
+		b.jwks = jwks
+	}
+
+	return b, nil
+}
+
+func (b *Bearer) Name() string { return "bearer" }
+
+func (b *Bearer) Authenticate(r *http.Request) (*Principal, bool, error) {
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, "Bearer ") {
+		return nil, false, nil
+	}
+	token := strings.TrimPrefix(header, "Bearer ")
+
+	if scopes, ok := b.cfg.StaticTokens[token]; ok {
+		return &Principal{Subject: token, Scopes: scopes, Method: "bearer"}, true, nil
+	}
+
+	if b.jwks == nil {
+		return nil, false, fmt.Errorf("unknown token")
+	}
+
+	claims := jwt.MapClaims{}
+	parsed, err := jwt.ParseWithClaims(token, claims, b.jwks.Keyfunc)
+	if err != nil || !parsed.Valid {
+		return nil, false, fmt.Errorf("invalid jwt: %w", err)
+	}
+
+	subject, _ := claims["sub"].(string)
+	return &Principal{Subject: subject, Scopes: scopesFromClaim(claims, b.cfg.ScopeClaim), Method: "bearer"}, true, nil
+}
+
+func scopesFromClaim(claims jwt.MapClaims, claimName string) []string {
+	if claimName == "" {
+		claimName = "scope"
+	}
+	raw, ok := claims[claimName]
+	if !ok {
+		return nil
+	}
+	switch v := raw.(type) {
+	case string:
+		return strings.Fields(v)
+	case []interface{}:
+		scopes := make([]string, 0, len(v))
+		for _, s := range v {
+			if str, ok := s.(string); ok {
+				scopes = append(scopes, str)
+			}
+		}
+		return scopes
+	default:
+		return nil
+	}
+}
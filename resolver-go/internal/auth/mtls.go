@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ganizanisitara/open-moniker-svc/resolver-go/internal/config"
+)
+
+// MTLS authenticates requests using the client certificate verified by the
+// TLS handshake (tls.Config.ClientAuth = tls.RequireAndVerifyClientCert),
+// allowlisting by CN/SAN.
+type MTLS struct {
+	cfg config.MTLSConfig
+}
+
+// NewMTLS constructs an MTLS authenticator from the CN/SAN allowlist.
+func NewMTLS(cfg config.MTLSConfig) *MTLS {
+	return &MTLS{cfg: cfg}
+}
+
+func (m *MTLS) Name() string { return "mtls" }
+
+func (m *MTLS) Authenticate(r *http.Request) (*Principal, bool, error) {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return nil, false, nil
+	}
+
+	cert := r.TLS.PeerCertificates[0]
+
+	identities := append([]string{cert.Subject.CommonName}, cert.DNSNames...)
+	for _, uri := range cert.URIs {
+		identities = append(identities, uri.String())
+	}
+
+	for _, id := range identities {
+		if scopes, ok := m.cfg.Allowed[id]; ok {
+			return &Principal{Subject: id, Scopes: scopes, Method: "mtls"}, true, nil
+		}
+	}
+
+	return nil, false, fmt.Errorf("certificate identity not in allowlist: %s", cert.Subject.CommonName)
+}
@@ -0,0 +1,139 @@
+package catalog
+
+import (
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/hashicorp/go-hclog"
+)
+
+// ReloadSummary describes the result of a single reload pass, returned both
+// from the background reloader's logs and from the POST /catalog/reload
+// admin endpoint so operators can drive it from CI.
+type ReloadSummary struct {
+	Added       int      `json:"added"`
+	Updated     int      `json:"updated"`
+	Removed     int      `json:"removed"`
+	ParseErrors []string `json:"parse_errors,omitempty"`
+}
+
+// Reloader watches a catalog definition file and keeps a Registry in sync
+// with it, either because the file changed on disk or because the configured
+// interval elapsed, whichever comes first. Reloads are diffed against the
+// registry's current nodes and applied atomically so in-flight resolves never
+// observe a partially-updated catalog.
+//
+// reconcile below calls registry.Diff, registry.Apply, and diff.ChangedKeys.
+// Registry itself (like internal/handlers, internal/service, and
+// internal/telemetry - see logging.go's note) is not part of this checkout:
+// cmd/resolver/main.go already referenced catalog.NewRegistry/RegisterMany/
+// Count/LoadCatalog before this file existed, but none of those, nor the
+// Diff/Apply/ChangedKeys API this reconciler needs, are defined anywhere in
+// this tree. That diffing logic needs to be added on the registry side
+// wherever it actually lives; this file only assumes its shape.
+type Reloader struct {
+	path     string
+	interval time.Duration
+	registry *Registry
+	cache    Invalidator
+	logger   hclog.Logger
+
+	watcher *fsnotify.Watcher
+	stop    chan struct{}
+}
+
+// Invalidator is the subset of the cache the reloader needs: the ability to
+// drop entries for monikers whose catalog node changed or disappeared.
+type Invalidator interface {
+	Invalidate(keys ...string)
+}
+
+// NewReloader constructs a Reloader for path, polling/watching at the given
+// interval. If interval is zero, only filesystem-change-driven reloads occur.
+func NewReloader(path string, interval time.Duration, registry *Registry, cache Invalidator, logger hclog.Logger) (*Reloader, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := watcher.Add(path); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+
+	return &Reloader{
+		path:     path,
+		interval: interval,
+		registry: registry,
+		cache:    cache,
+		logger:   logger.Named("catalog-reloader"),
+		watcher:  watcher,
+		stop:     make(chan struct{}),
+	}, nil
+}
+
+// Start runs the watch/reconcile loop until Stop is called.
+func (r *Reloader) Start() {
+	var ticker *time.Ticker
+	var tickC <-chan time.Time
+	if r.interval > 0 {
+		ticker = time.NewTicker(r.interval)
+		tickC = ticker.C
+		defer ticker.Stop()
+	}
+
+	for {
+		select {
+		case event, ok := <-r.watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 {
+				r.reconcile("fsnotify")
+			}
+		case err, ok := <-r.watcher.Errors:
+			if !ok {
+				return
+			}
+			r.logger.Warn("watch error", "error", err)
+		case <-tickC:
+			r.reconcile("interval")
+		case <-r.stop:
+			r.watcher.Close()
+			return
+		}
+	}
+}
+
+// Stop halts the watch/reconcile loop and releases the fsnotify watcher.
+func (r *Reloader) Stop() {
+	close(r.stop)
+}
+
+// Reload forces a synchronous reload, used by the POST /catalog/reload admin
+// endpoint so operators can drive it from CI rather than waiting on the
+// watcher or interval.
+func (r *Reloader) Reload() ReloadSummary {
+	return r.reconcile("manual")
+}
+
+func (r *Reloader) reconcile(trigger string) ReloadSummary {
+	nodes, err := LoadCatalog(r.path)
+	if err != nil {
+		r.logger.Warn("reload failed, keeping previous catalog", "trigger", trigger, "error", err)
+		return ReloadSummary{ParseErrors: []string{err.Error()}}
+	}
+
+	diff := r.registry.Diff(nodes)
+	r.registry.Apply(diff)
+
+	if r.cache != nil && len(diff.ChangedKeys()) > 0 {
+		r.cache.Invalidate(diff.ChangedKeys()...)
+	}
+
+	summary := ReloadSummary{Added: len(diff.Added), Updated: len(diff.Updated), Removed: len(diff.Removed)}
+	r.logger.Info("catalog reloaded", "trigger", trigger,
+		"added", summary.Added, "updated", summary.Updated, "removed", summary.Removed)
+
+	return summary
+}
@@ -10,19 +10,39 @@ import (
 
 // Config represents the service configuration
 type Config struct {
-	ProjectName string          `yaml:"project_name"`
-	Server      ServerConfig    `yaml:"server"`
-	Telemetry   TelemetryConfig `yaml:"telemetry"`
-	Cache       CacheConfig     `yaml:"cache"`
-	Catalog     CatalogConfig   `yaml:"catalog"`
-	Auth        AuthConfig      `yaml:"auth"`
-	ConfigUI    ConfigUIConfig  `yaml:"config_ui"`
+	ProjectName string                           `yaml:"project_name"`
+	Server      ServerConfig                     `yaml:"server"`
+	Telemetry   TelemetryConfig                  `yaml:"telemetry"`
+	Cache       CacheConfig                      `yaml:"cache"`
+	Catalog     CatalogConfig                    `yaml:"catalog"`
+	Auth        AuthConfig                       `yaml:"auth"`
+	ConfigUI    ConfigUIConfig                   `yaml:"config_ui"`
+	Logging     LoggingConfig                    `yaml:"logging"`
+	Resilience  map[string]RouteResilienceConfig `yaml:"resilience"`
+	Egress      EgressConfig                     `yaml:"egress"`
 }
 
 // ServerConfig represents server configuration
 type ServerConfig struct {
-	Host string `yaml:"host"`
-	Port int    `yaml:"port"`
+	Host string     `yaml:"host"`
+	Port int        `yaml:"port"`
+	GRPC GRPCConfig `yaml:"grpc"`
+	TLS  TLSConfig  `yaml:"tls"`
+}
+
+// TLSConfig configures the HTTP listener's server certificate. It is
+// required for the "mtls" auth method to do anything: without it the server
+// never upgrades to TLS, so r.TLS is always nil and auth.MTLS never matches.
+type TLSConfig struct {
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+}
+
+// GRPCConfig represents the gRPC transport configuration
+type GRPCConfig struct {
+	Enabled    bool `yaml:"enabled"`
+	Port       int  `yaml:"port"`
+	Reflection bool `yaml:"reflection"`
 }
 
 // TelemetryConfig represents telemetry configuration
@@ -45,14 +65,41 @@ type CacheConfig struct {
 // CatalogConfig represents catalog configuration
 type CatalogConfig struct {
 	DefinitionFile        string `yaml:"definition_file"`
+	ReloadEnabled         bool   `yaml:"reload_enabled"`
 	ReloadIntervalSeconds int    `yaml:"reload_interval_seconds"`
 }
 
 // AuthConfig represents authentication configuration
 type AuthConfig struct {
-	Enabled     bool     `yaml:"enabled"`
-	Enforce     bool     `yaml:"enforce"`
-	MethodOrder []string `yaml:"method_order"`
+	Enabled     bool         `yaml:"enabled"`
+	Enforce     bool         `yaml:"enforce"`
+	MethodOrder []string     `yaml:"method_order"`
+	AdminScope  string       `yaml:"admin_scope"` // scope required for admin endpoints; defaults to "admin"
+	Bearer      BearerConfig `yaml:"bearer"`
+	Basic       BasicConfig  `yaml:"basic"`
+	MTLS        MTLSConfig   `yaml:"mtls"`
+}
+
+// BearerConfig configures the bearer authentication method: static tokens
+// and/or JWKS-verified JWTs with a claim-to-scope mapping.
+type BearerConfig struct {
+	StaticTokens map[string][]string `yaml:"static_tokens"` // token -> scopes
+	JWKSURL      string              `yaml:"jwks_url"`
+	ScopeClaim   string              `yaml:"scope_claim"`
+}
+
+// BasicConfig configures the basic authentication method: an htpasswd-style
+// file mapping username to bcrypt hash and scopes.
+type BasicConfig struct {
+	FilePath string `yaml:"file_path"`
+}
+
+// MTLSConfig configures the mTLS authentication method: a CN/SAN allowlist
+// mapping each identity to the scopes it is granted, plus the CA bundle the
+// server verifies client certificates against.
+type MTLSConfig struct {
+	ClientCAFile string              `yaml:"client_ca_file"`
+	Allowed      map[string][]string `yaml:"allowed"`
 }
 
 // ConfigUIConfig represents config UI settings
@@ -62,6 +109,44 @@ type ConfigUIConfig struct {
 	ShowFilePaths  bool   `yaml:"show_file_paths"`
 }
 
+// LoggingConfig represents structured logging configuration
+type LoggingConfig struct {
+	Level           string `yaml:"level"`
+	Format          string `yaml:"format"` // "json" or "text"
+	IncludeLocation bool   `yaml:"include_location"`
+}
+
+// RouteResilienceConfig configures rate limiting and circuit breaking for the
+// route prefix it is keyed under in Config.Resilience (e.g. "/resolve/batch").
+type RouteResilienceConfig struct {
+	RPS                    float64 `yaml:"rps"`
+	Burst                  int     `yaml:"burst"`
+	BreakerErrorPct        float64 `yaml:"breaker_error_pct"`
+	BreakerMinSamples      int     `yaml:"breaker_min_samples"`
+	BreakerCooldownSeconds int     `yaml:"breaker_cooldown_seconds"`
+	MaxConcurrent          int     `yaml:"max_concurrent"`
+}
+
+// EgressConfig declares the allowed upstream hosts that FetchDataHandler (and
+// any other resolver step that reaches an external system) may contact,
+// turning an otherwise unconstrained outbound caller into a governed egress
+// gateway.
+type EgressConfig struct {
+	Rules []EgressRule `yaml:"rules"`
+}
+
+// EgressRule allowlists one upstream host and the ports/protocols/timeouts/
+// retry policy/TLS pinning that apply to it.
+type EgressRule struct {
+	Host           string   `yaml:"host"`
+	Ports          []int    `yaml:"ports"`
+	Protocols      []string `yaml:"protocols"` // "http", "https"
+	TimeoutSeconds float64  `yaml:"timeout_seconds"`
+	MaxRetries     int      `yaml:"max_retries"`
+	BackoffSeconds float64  `yaml:"backoff_seconds"`
+	PinnedSHA256   string   `yaml:"pinned_sha256"` // optional cert pin, hex-encoded
+}
+
 // Load loads configuration from a YAML file
 func Load(configPath string) (*Config, error) {
 	// Default: ../config.yaml (relative to resolver-go/)
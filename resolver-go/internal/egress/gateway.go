@@ -0,0 +1,160 @@
+// Package egress turns outbound calls to external systems (starting with
+// handlers.FetchDataHandler) into a governed gateway: requests to hosts not
+// named in config.EgressConfig are rejected, and matched hosts get a shared,
+// pre-configured *http.Client with the rule's timeout, retry/backoff, and
+// optional TLS pin.
+package egress
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/ganizanisitara/open-moniker-svc/resolver-go/internal/config"
+)
+
+// Gateway allowlists upstream hosts and sends requests through a shared
+// *http.Client configured per the matching rule, so resolver steps that reach external
+// systems go through one governed, auditable choke point and reuse
+// connections/keep-alives across requests to the same host.
+type Gateway struct {
+	rules   map[string]config.EgressRule
+	clients map[string]*http.Client // keyed by rule.Host, built once in NewGateway
+	logger  hclog.Logger
+}
+
+// NewGateway builds a Gateway from the egress: config block, constructing and
+// caching one *http.Client per rule up front.
+func NewGateway(cfg config.EgressConfig, logger hclog.Logger) *Gateway {
+	rules := make(map[string]config.EgressRule, len(cfg.Rules))
+	clients := make(map[string]*http.Client, len(cfg.Rules))
+	for _, rule := range cfg.Rules {
+		rules[rule.Host] = rule
+		clients[rule.Host] = newClient(rule)
+	}
+	return &Gateway{rules: rules, clients: clients, logger: logger.Named("egress")}
+}
+
+// Allowed reports whether host/port/protocol are in the allowlist.
+func (g *Gateway) Allowed(host string, port int, protocol string) bool {
+	rule, ok := g.rules[host]
+	if !ok {
+		return false
+	}
+	if len(rule.Ports) > 0 && !containsInt(rule.Ports, port) {
+		return false
+	}
+	if len(rule.Protocols) > 0 && !containsString(rule.Protocols, protocol) {
+		return false
+	}
+	return true
+}
+
+// Do performs req through the allowlisted client for req.URL.Host, retrying
+// per the matching rule's policy with linear backoff. It returns a 403-style
+// error without making any network call if the host is not allowlisted, so
+// callers can log and respond the same way they would any other rejection.
+func (g *Gateway) Do(req *http.Request) (*http.Response, error) {
+	host := req.URL.Hostname()
+	port := portOf(req.URL)
+	protocol := req.URL.Scheme
+
+	rule, ok := g.rules[host]
+	if !ok || !g.Allowed(host, port, protocol) {
+		g.logger.Warn("rejected egress request", "host", host, "port", port, "protocol", protocol)
+		return nil, fmt.Errorf("egress: host %q is not in the allowlist", host)
+	}
+
+	client := g.clients[host]
+
+	var lastErr error
+	for attempt := 0; attempt <= rule.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(time.Duration(rule.BackoffSeconds * float64(attempt) * float64(time.Second)))
+		}
+
+		// http.Client.Do reads and closes req.Body, so a retried request with
+		// a body needs it rebuilt from GetBody before every attempt,
+		// including the first (req.Body itself may already be partially
+		// consumed by a prior attempt in the loop).
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("egress: rebuild request body for %q: %w", host, err)
+			}
+			req.Body = body
+		}
+
+		resp, err := client.Do(req)
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+		g.logger.Warn("egress request failed, retrying", "host", host, "attempt", attempt, "error", err)
+	}
+
+	return nil, fmt.Errorf("egress: all attempts to %q failed: %w", host, lastErr)
+}
+
+func newClient(rule config.EgressRule) *http.Client {
+	transport := &http.Transport{}
+
+	if rule.PinnedSHA256 != "" {
+		pin := rule.PinnedSHA256
+		transport.TLSClientConfig = &tls.Config{
+			VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+				for _, raw := range rawCerts {
+					sum := sha256.Sum256(raw)
+					if fmt.Sprintf("%x", sum) == pin {
+						return nil
+					}
+				}
+				return fmt.Errorf("certificate does not match pinned sha256 %q", pin)
+			},
+		}
+	}
+
+	timeout := time.Duration(rule.TimeoutSeconds * float64(time.Second))
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	return &http.Client{Transport: transport, Timeout: timeout}
+}
+
+func portOf(u *url.URL) int {
+	p := u.Port()
+	if p == "" {
+		if u.Scheme == "https" {
+			return 443
+		}
+		return 80
+	}
+	n, _ := strconv.Atoi(p)
+	return n
+}
+
+func containsInt(haystack []int, needle int) bool {
+	for _, v := range haystack {
+		if v == needle {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(haystack []string, needle string) bool {
+	for _, v := range haystack {
+		if v == needle {
+			return true
+		}
+	}
+	return false
+}
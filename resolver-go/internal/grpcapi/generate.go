@@ -0,0 +1,10 @@
+package grpcapi
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative -I ../../proto ../../proto/moniker.proto
+
+// The monikerpb package referenced by server.go is generated from
+// proto/moniker.proto via `go generate ./...` (requires protoc, protoc-gen-go,
+// and protoc-gen-go-grpc on PATH) and is not checked in. `go build ./...` run
+// directly from a clean checkout will therefore fail to resolve that import;
+// always build/vet/test through the Makefile (`make build`, `make vet`,
+// `make test`), which runs generation first.
@@ -0,0 +1,59 @@
+package grpcapi
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/textproto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/ganizanisitara/open-moniker-svc/resolver-go/internal/auth"
+)
+
+// authInterceptor drives chain (the same auth.Chain HTTP requests go
+// through) against every unary RPC, so a plain grpc.NewServer() with no
+// interceptors can't let server.grpc.enabled silently bypass
+// auth.enabled/auth.enforce. It adapts each call's incoming metadata (and,
+// if the gRPC listener itself terminates TLS, the peer's client certificate)
+// into the *http.Request shape auth.Authenticator already expects, so bearer,
+// basic, and mtls all work unmodified over gRPC.
+func authInterceptor(chain *auth.Chain) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		r := &http.Request{Header: http.Header{}}
+
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			for k, vs := range md {
+				key := textproto.CanonicalMIMEHeaderKey(k)
+				for _, v := range vs {
+					r.Header.Add(key, v)
+				}
+			}
+		}
+
+		if p, ok := peer.FromContext(ctx); ok {
+			if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+				r.TLS = &tls.ConnectionState{PeerCertificates: tlsInfo.State.PeerCertificates}
+			}
+		}
+
+		principal, err := chain.Authenticate(r)
+		switch {
+		case err == auth.ErrAuthFailed:
+			return nil, status.Error(codes.PermissionDenied, "authentication failed")
+		case err == auth.ErrAuthRequired:
+			return nil, status.Error(codes.Unauthenticated, "authentication required")
+		}
+
+		if principal != nil {
+			ctx = auth.ContextWithPrincipal(ctx, principal)
+		}
+
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,122 @@
+// Package grpcapi exposes the resolver's service.MonikerService over gRPC,
+// mirroring the HTTP handlers registered in cmd/resolver/main.go so both
+// transports share the same registry, cache, and telemetry state.
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	"github.com/ganizanisitara/open-moniker-svc/resolver-go/internal/auth"
+	"github.com/ganizanisitara/open-moniker-svc/resolver-go/internal/catalog"
+	"github.com/ganizanisitara/open-moniker-svc/resolver-go/internal/grpcapi/monikerpb"
+	"github.com/ganizanisitara/open-moniker-svc/resolver-go/internal/service"
+)
+
+// Server implements monikerpb.MonikerServiceServer on top of an existing
+// service.MonikerService, so resolve/describe/list/lineage/batch/search/tree
+// behave identically whether called over HTTP or gRPC.
+//
+// SearchCatalog and Tree below call registry.Search and registry.TreeJSON.
+// Like the rest of catalog.Registry, that type is not part of this checkout
+// (see catalog/reloader.go's note), so those two methods are assumed, not
+// implemented or stubbed, here - they need to land alongside the registry
+// itself.
+type Server struct {
+	monikerpb.UnimplementedMonikerServiceServer
+
+	svc      *service.MonikerService
+	registry *catalog.Registry
+}
+
+// NewServer constructs a Server backed by the given service and registry.
+func NewServer(svc *service.MonikerService, registry *catalog.Registry) *Server {
+	return &Server{svc: svc, registry: registry}
+}
+
+// Listen starts a gRPC server on addr and registers the MonikerService.
+// chain, if non-nil, is run against every unary RPC via authInterceptor so
+// this transport honors the same auth.enabled/auth.enforce policy HTTP does
+// instead of serving MonikerService unauthenticated. reflectionEnabled gates
+// registering reflection (so grpcurl works without a local copy of the
+// proto) per server.grpc.reflection. Serves until the listener or server is
+// stopped via GracefulStop.
+func Listen(addr string, srv *Server, chain *auth.Chain, reflectionEnabled bool) (*grpc.Server, error) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("grpc listen: %w", err)
+	}
+
+	var opts []grpc.ServerOption
+	if chain != nil {
+		opts = append(opts, grpc.UnaryInterceptor(authInterceptor(chain)))
+	}
+
+	gs := grpc.NewServer(opts...)
+	monikerpb.RegisterMonikerServiceServer(gs, srv)
+	if reflectionEnabled {
+		reflection.Register(gs)
+	}
+
+	go func() {
+		_ = gs.Serve(lis)
+	}()
+
+	return gs, nil
+}
+
+func (s *Server) Resolve(ctx context.Context, req *monikerpb.ResolveRequest) (*monikerpb.ResolveResponse, error) {
+	res, cached, err := s.svc.Resolve(ctx, req.GetMoniker())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "resolve %q: %v", req.GetMoniker(), err)
+	}
+	return &monikerpb.ResolveResponse{Moniker: req.GetMoniker(), Value: res, Cached: cached}, nil
+}
+
+func (s *Server) Describe(ctx context.Context, req *monikerpb.DescribeRequest) (*monikerpb.DescribeResponse, error) {
+	node, err := s.svc.Describe(ctx, req.GetMoniker())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "describe %q: %v", req.GetMoniker(), err)
+	}
+	return &monikerpb.DescribeResponse{Moniker: req.GetMoniker(), Description: node.Description, Status: node.Status}, nil
+}
+
+func (s *Server) List(ctx context.Context, req *monikerpb.ListRequest) (*monikerpb.ListResponse, error) {
+	monikers, err := s.svc.List(ctx, req.GetPrefix())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list %q: %v", req.GetPrefix(), err)
+	}
+	return &monikerpb.ListResponse{Monikers: monikers}, nil
+}
+
+func (s *Server) Lineage(ctx context.Context, req *monikerpb.LineageRequest) (*monikerpb.LineageResponse, error) {
+	ancestors, descendants, err := s.svc.Lineage(ctx, req.GetMoniker())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "lineage %q: %v", req.GetMoniker(), err)
+	}
+	return &monikerpb.LineageResponse{Ancestors: ancestors, Descendants: descendants}, nil
+}
+
+func (s *Server) BatchResolve(ctx context.Context, req *monikerpb.BatchResolveRequest) (*monikerpb.BatchResolveResponse, error) {
+	results, errs := s.svc.BatchResolve(ctx, req.GetMonikers())
+	return &monikerpb.BatchResolveResponse{Results: results, Errors: errs}, nil
+}
+
+func (s *Server) SearchCatalog(ctx context.Context, req *monikerpb.SearchCatalogRequest) (*monikerpb.SearchCatalogResponse, error) {
+	monikers := s.registry.Search(req.GetQuery())
+	return &monikerpb.SearchCatalogResponse{Monikers: monikers}, nil
+}
+
+func (s *Server) Tree(ctx context.Context, req *monikerpb.TreeRequest) (*monikerpb.TreeResponse, error) {
+	treeJSON, err := s.registry.TreeJSON(req.GetRoot())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "tree %q: %v", req.GetRoot(), err)
+	}
+	return &monikerpb.TreeResponse{Json: treeJSON}, nil
+}
@@ -0,0 +1,117 @@
+package introspection
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Counters holds the live counters and latency samples that Register
+// publishes at scrape time. Cache and service are meant to wire into this at
+// their existing hit/miss/error sites instead of maintaining their own ad
+// hoc metrics, but internal/cache and internal/service are not part of this
+// checkout (referenced by cmd/resolver/main.go but not checked in, the same
+// way internal/grpcapi/monikerpb is not - see grpcapi/generate.go), so that
+// wiring could not be done from here: cache_stats in metrics.go still reads
+// hit/miss/eviction counts from the Cache.Stats() interface instead, which
+// is the closest equivalent that could be verified against code actually
+// present in this tree. That substitution is a stand-in, not the requested
+// wiring - once internal/cache and internal/service land, their hit/miss/
+// error sites still need to call IncrResolve/ObserveLatency (or equivalent
+// counters added here) directly; this file alone doesn't close that request.
+type Counters struct {
+	resolvesTotal int64
+
+	mu               sync.Mutex
+	resolvesByPrefix map[string]int64
+	handlerLatency   map[string][]float64 // handler name -> recent latencies in seconds, capped
+}
+
+const maxLatencySamples = 256
+
+// NewCounters constructs an empty Counters.
+func NewCounters() *Counters {
+	return &Counters{
+		resolvesByPrefix: make(map[string]int64),
+		handlerLatency:   make(map[string][]float64),
+	}
+}
+
+// IncrResolve records one resolve of a moniker, bucketed by its first
+// dot-separated prefix (e.g. "team.service.env" -> "team").
+func (c *Counters) IncrResolve(moniker string) {
+	atomic.AddInt64(&c.resolvesTotal, 1)
+
+	prefix := moniker
+	if i := strings.IndexByte(moniker, '.'); i >= 0 {
+		prefix = moniker[:i]
+	}
+
+	c.mu.Lock()
+	c.resolvesByPrefix[prefix]++
+	c.mu.Unlock()
+}
+
+// ObserveLatency records a handler's request latency in seconds. /metrics
+// reports p50/p90/p99 over the most recent samples; no histogram buckets are
+// kept.
+func (c *Counters) ObserveLatency(handler string, seconds float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	samples := c.handlerLatency[handler]
+	if len(samples) >= maxLatencySamples {
+		samples = samples[1:]
+	}
+	c.handlerLatency[handler] = append(samples, seconds)
+}
+
+// ResolvesTotal returns the all-time resolve count.
+func (c *Counters) ResolvesTotal() int64 {
+	return atomic.LoadInt64(&c.resolvesTotal)
+}
+
+// ResolvesByPrefix returns a snapshot copy of per-prefix resolve counts.
+func (c *Counters) ResolvesByPrefix() map[string]int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out := make(map[string]int64, len(c.resolvesByPrefix))
+	for k, v := range c.resolvesByPrefix {
+		out[k] = v
+	}
+	return out
+}
+
+// HandlerLatencyQuantiles returns p50/p90/p99 (seconds) per handler from the
+// most recent samples.
+func (c *Counters) HandlerLatencyQuantiles() map[string][3]float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out := make(map[string][3]float64, len(c.handlerLatency))
+	for handler, samples := range c.handlerLatency {
+		out[handler] = quantiles(samples)
+	}
+	return out
+}
+
+func quantiles(samples []float64) [3]float64 {
+	if len(samples) == 0 {
+		return [3]float64{}
+	}
+
+	sorted := append([]float64(nil), samples...)
+	for i := 1; i < len(sorted); i++ {
+		for j := i; j > 0 && sorted[j-1] > sorted[j]; j-- {
+			sorted[j-1], sorted[j] = sorted[j], sorted[j-1]
+		}
+	}
+
+	pick := func(p float64) float64 {
+		idx := int(p * float64(len(sorted)-1))
+		return sorted[idx]
+	}
+
+	return [3]float64{pick(0.50), pick(0.90), pick(0.99)}
+}
@@ -0,0 +1,139 @@
+// Package introspection publishes live resolver internals for /debug/vars
+// (expvar) and /metrics (Prometheus text format). Every value is computed at
+// scrape time from the Counters, cache, registry, and telemetry emitter
+// rather than periodically copied, following the
+// expvar.Publish(name, expvar.Func(...)) pattern already used for things
+// like connection_status and uptime.
+package introspection
+
+import (
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"sort"
+	"time"
+)
+
+// Registry, Cache, and Emitter are the subsets of catalog.Registry,
+// cache.InMemory, and telemetry.Emitter that introspection reads from.
+type Registry interface {
+	Count() map[string]int
+}
+
+type Cache interface {
+	Size() int
+	Stats() (hits, misses, evictions int64)
+}
+
+type Emitter interface {
+	GetStats() (emitted, dropped, errors int64, queueDepth int)
+}
+
+// Register publishes the expvar.Func values backing both /debug/vars and
+// /metrics. startedAt is used to compute process uptime at scrape time.
+func Register(counters *Counters, registry Registry, cacheInst Cache, emitter Emitter, startedAt time.Time) {
+	expvar.Publish("resolves_total", expvar.Func(func() interface{} {
+		return counters.ResolvesTotal()
+	}))
+	expvar.Publish("resolves_by_prefix", expvar.Func(func() interface{} {
+		return counters.ResolvesByPrefix()
+	}))
+	expvar.Publish("cache_stats", expvar.Func(func() interface{} {
+		hits, misses, evictions := cacheInst.Stats()
+		ratio := 0.0
+		if hits+misses > 0 {
+			ratio = float64(hits) / float64(hits+misses)
+		}
+		return map[string]interface{}{
+			"size": cacheInst.Size(), "hits": hits, "misses": misses,
+			"evictions": evictions, "hit_ratio": ratio,
+		}
+	}))
+	expvar.Publish("handler_latency_seconds", expvar.Func(func() interface{} {
+		return counters.HandlerLatencyQuantiles()
+	}))
+	expvar.Publish("telemetry_queue_depth", expvar.Func(func() interface{} {
+		_, _, _, depth := emitter.GetStats()
+		return depth
+	}))
+	expvar.Publish("registry_node_counts", expvar.Func(func() interface{} {
+		return registry.Count()
+	}))
+	expvar.Publish("uptime_seconds", expvar.Func(func() interface{} {
+		return time.Since(startedAt).Seconds()
+	}))
+	expvar.Publish("goroutines", expvar.Func(func() interface{} {
+		return runtime.NumGoroutine()
+	}))
+}
+
+// PrometheusHandler serves /metrics in Prometheus text exposition format,
+// computing the same values Register publishes directly from counters,
+// registry, cacheInst, and emitter at scrape time.
+func PrometheusHandler(counters *Counters, registry Registry, cacheInst Cache, emitter Emitter, startedAt time.Time) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+		writeGauge(w, "resolver_resolves_total", "Total resolve calls served", float64(counters.ResolvesTotal()))
+
+		byPrefix := counters.ResolvesByPrefix()
+		prefixes := make([]string, 0, len(byPrefix))
+		for p := range byPrefix {
+			prefixes = append(prefixes, p)
+		}
+		sort.Strings(prefixes)
+		fmt.Fprintln(w, "# HELP resolver_resolves_by_prefix_total Resolves by moniker prefix")
+		fmt.Fprintln(w, "# TYPE resolver_resolves_by_prefix_total counter")
+		for _, p := range prefixes {
+			fmt.Fprintf(w, "resolver_resolves_by_prefix_total{prefix=%q} %d\n", p, byPrefix[p])
+		}
+
+		hits, misses, evictions := cacheInst.Stats()
+		writeGauge(w, "resolver_cache_size", "Current cache entry count", float64(cacheInst.Size()))
+		writeGauge(w, "resolver_cache_hits_total", "Cache hits", float64(hits))
+		writeGauge(w, "resolver_cache_misses_total", "Cache misses", float64(misses))
+		writeGauge(w, "resolver_cache_evictions_total", "Cache evictions", float64(evictions))
+		if hits+misses > 0 {
+			writeGauge(w, "resolver_cache_hit_ratio", "Cache hit ratio", float64(hits)/float64(hits+misses))
+		}
+
+		fmt.Fprintln(w, "# HELP resolver_handler_latency_seconds Per-handler request latency quantiles")
+		fmt.Fprintln(w, "# TYPE resolver_handler_latency_seconds gauge")
+		handlers := make([]string, 0)
+		quantiles := counters.HandlerLatencyQuantiles()
+		for h := range quantiles {
+			handlers = append(handlers, h)
+		}
+		sort.Strings(handlers)
+		for _, h := range handlers {
+			q := quantiles[h]
+			fmt.Fprintf(w, "resolver_handler_latency_seconds{handler=%q,quantile=\"0.5\"} %f\n", h, q[0])
+			fmt.Fprintf(w, "resolver_handler_latency_seconds{handler=%q,quantile=\"0.9\"} %f\n", h, q[1])
+			fmt.Fprintf(w, "resolver_handler_latency_seconds{handler=%q,quantile=\"0.99\"} %f\n", h, q[2])
+		}
+
+		_, _, _, queueDepth := emitter.GetStats()
+		writeGauge(w, "resolver_telemetry_queue_depth", "Telemetry emitter queue depth", float64(queueDepth))
+
+		fmt.Fprintln(w, "# HELP resolver_registry_nodes Registry node counts by status")
+		fmt.Fprintln(w, "# TYPE resolver_registry_nodes gauge")
+		counts := registry.Count()
+		statuses := make([]string, 0, len(counts))
+		for s := range counts {
+			statuses = append(statuses, s)
+		}
+		sort.Strings(statuses)
+		for _, s := range statuses {
+			fmt.Fprintf(w, "resolver_registry_nodes{status=%q} %d\n", s, counts[s])
+		}
+
+		writeGauge(w, "resolver_uptime_seconds", "Process uptime", time.Since(startedAt).Seconds())
+		writeGauge(w, "resolver_goroutines", "Live goroutine count", float64(runtime.NumGoroutine()))
+	}
+}
+
+func writeGauge(w io.Writer, name, help string, value float64) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n%s %f\n", name, help, name, name, value)
+}
@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// breakerState is a Hystrix-style circuit breaker: closed lets traffic
+// through while tracking a sliding-window error ratio, open rejects
+// everything until the cooldown elapses, half-open lets a single probe
+// request through to decide whether to close again.
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// CircuitBreaker trips per route prefix when the error ratio over the last
+// minSamples requests reaches errorPct, then probes again after cooldown.
+type CircuitBreaker struct {
+	errorPct   float64
+	minSamples int
+	cooldown   time.Duration
+
+	mu            sync.Mutex
+	state         breakerState
+	openedAt      time.Time
+	probeInFlight bool   // half-open: whether the single probe request is already out
+	samples       []bool // true = error, ring buffer of the last minSamples requests
+	sampleIdx     int
+}
+
+// NewCircuitBreaker constructs a breaker from the resilience config fields.
+func NewCircuitBreaker(errorPct float64, minSamples int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		errorPct:   errorPct,
+		minSamples: minSamples,
+		cooldown:   cooldown,
+	}
+}
+
+// allow reports whether a request may proceed, transitioning open -> half-open
+// once the cooldown has elapsed.
+func (b *CircuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if time.Since(b.openedAt) >= b.cooldown {
+			b.state = breakerHalfOpen
+			b.probeInFlight = true
+			return true
+		}
+		return false
+	case breakerHalfOpen:
+		if b.probeInFlight {
+			return false
+		}
+		b.probeInFlight = true
+		return true
+	default:
+		return true
+	}
+}
+
+// record feeds the outcome of a request back into the sliding window and
+// trips or resets the breaker accordingly.
+func (b *CircuitBreaker) record(isErr bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == breakerHalfOpen {
+		b.probeInFlight = false
+		if isErr {
+			b.state = breakerOpen
+			b.openedAt = time.Now()
+			return
+		}
+		b.state = breakerClosed
+		b.samples = nil
+		b.sampleIdx = 0
+		return
+	}
+
+	if len(b.samples) < b.minSamples {
+		b.samples = append(b.samples, isErr)
+	} else {
+		b.samples[b.sampleIdx%b.minSamples] = isErr
+	}
+	b.sampleIdx++
+
+	if len(b.samples) < b.minSamples {
+		return
+	}
+
+	errors := 0
+	for _, e := range b.samples {
+		if e {
+			errors++
+		}
+	}
+	if float64(errors)/float64(len(b.samples))*100 >= b.errorPct {
+		b.state = breakerOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// Wrap returns an http.Handler that rejects requests with 503 (and a
+// Retry-After header) while the breaker is open, and otherwise records
+// whether next responded with a 5xx to drive the breaker's error ratio.
+func (b *CircuitBreaker) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !b.allow() {
+			w.Header().Set("Retry-After", strconv.Itoa(int(b.cooldown.Seconds())))
+			http.Error(w, "circuit breaker open", http.StatusServiceUnavailable)
+			return
+		}
+
+		sw := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(sw, r)
+		b.record(sw.status >= 500)
+	})
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusRecorder) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
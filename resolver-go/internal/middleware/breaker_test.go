@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func serve(b *CircuitBreaker, status int) int {
+	rec := httptest.NewRecorder()
+	b.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	})).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	return rec.Code
+}
+
+func TestCircuitBreakerTripsAfterErrorRatioExceeded(t *testing.T) {
+	b := NewCircuitBreaker(50, 4, time.Minute)
+
+	// 2 of 4 errors is exactly at the 50% threshold and should trip the
+	// breaker to open on the sample that crosses it.
+	for i := 0; i < 2; i++ {
+		if code := serve(b, http.StatusOK); code != http.StatusOK {
+			t.Fatalf("request %d: got %d, want 200", i, code)
+		}
+	}
+	serve(b, http.StatusInternalServerError)
+	serve(b, http.StatusInternalServerError)
+
+	if code := serve(b, http.StatusOK); code != http.StatusServiceUnavailable {
+		t.Fatalf("breaker should be open after error ratio exceeded, got %d", code)
+	}
+}
+
+func TestCircuitBreakerHalfOpenAllowsOnlyOneProbe(t *testing.T) {
+	b := NewCircuitBreaker(1, 1, 10*time.Millisecond)
+
+	// One failing request at minSamples=1 trips the breaker open.
+	serve(b, http.StatusInternalServerError)
+	if code := serve(b, http.StatusOK); code != http.StatusServiceUnavailable {
+		t.Fatalf("breaker should be open, got %d", code)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	// allow() itself (not Wrap, which would record and resolve the
+	// half-open state) is exercised directly here so a second caller can be
+	// observed finding the probe already in flight.
+	if !b.allow() {
+		t.Fatal("first call after cooldown should be allowed as the probe")
+	}
+	if b.allow() {
+		t.Fatal("second concurrent call during half-open should be rejected, only one probe may be in flight")
+	}
+}
+
+func TestCircuitBreakerHalfOpenProbeSuccessCloses(t *testing.T) {
+	b := NewCircuitBreaker(1, 1, 10*time.Millisecond)
+
+	serve(b, http.StatusInternalServerError)
+	time.Sleep(20 * time.Millisecond)
+
+	if code := serve(b, http.StatusOK); code != http.StatusOK {
+		t.Fatalf("probe request should be let through, got %d", code)
+	}
+	if code := serve(b, http.StatusOK); code != http.StatusOK {
+		t.Fatalf("breaker should be closed after successful probe, got %d", code)
+	}
+}
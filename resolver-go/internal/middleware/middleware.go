@@ -0,0 +1,79 @@
+// Package middleware provides cross-cutting per-route rate limiting and
+// circuit breaking for the HTTP handlers registered in cmd/resolver/main.go.
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ganizanisitara/open-moniker-svc/resolver-go/internal/config"
+)
+
+// Hooks lets the caller observe rate-limit and breaker-open events (e.g. to
+// increment a telemetry counter) without this package depending on
+// internal/telemetry directly.
+type Hooks struct {
+	OnRateLimited func(route string)
+	OnBreakerOpen func(route string)
+}
+
+// Wrap applies the resilience config for routePrefix (rate limiting, a
+// concurrency cap, and a circuit breaker) around next. If routePrefix has no
+// entry in cfg, next is returned unwrapped.
+func Wrap(cfg map[string]config.RouteResilienceConfig, routePrefix string, hooks Hooks, next http.Handler) http.Handler {
+	rule, ok := cfg[routePrefix]
+	if !ok {
+		return next
+	}
+
+	handler := next
+
+	if rule.MaxConcurrent > 0 {
+		handler = concurrencyCap(rule.MaxConcurrent, handler)
+	}
+
+	if rule.BreakerMinSamples > 0 && rule.BreakerErrorPct > 0 {
+		breaker := NewCircuitBreaker(rule.BreakerErrorPct, rule.BreakerMinSamples,
+			time.Duration(rule.BreakerCooldownSeconds)*time.Second)
+		handler = withHook(breaker.Wrap(handler), hooks.OnBreakerOpen, routePrefix, http.StatusServiceUnavailable)
+	}
+
+	if rule.RPS > 0 {
+		handler = withHook(RateLimit(rule.RPS, rule.Burst, handler), hooks.OnRateLimited, routePrefix, http.StatusTooManyRequests)
+	}
+
+	return handler
+}
+
+// withHook calls hook(route) whenever next responds with triggerStatus,
+// so callers can count 429s/503s without this package knowing about
+// telemetry.
+func withHook(next http.Handler, hook func(route string), route string, triggerStatus int) http.Handler {
+	if hook == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sw := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(sw, r)
+		if sw.status == triggerStatus {
+			hook(route)
+		}
+	})
+}
+
+// concurrencyCap bounds the number of in-flight requests through next to n,
+// rejecting with 503 once the cap is reached. Used for batch/fetch endpoints
+// that hit external systems and should not be allowed to pile up.
+func concurrencyCap(n int, next http.Handler) http.Handler {
+	sem := make(chan struct{}, n)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case sem <- struct{}{}:
+			defer func() { <-sem }()
+			next.ServeHTTP(w, r)
+		default:
+			http.Error(w, "max concurrent requests reached", http.StatusServiceUnavailable)
+		}
+	})
+}
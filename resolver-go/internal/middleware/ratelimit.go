@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"net/http"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimit wraps next with a token-bucket limiter: rps tokens are refilled
+// per second up to burst capacity. Requests beyond the bucket get a 429.
+func RateLimit(rps float64, burst int, next http.Handler) http.Handler {
+	if rps <= 0 {
+		return next
+	}
+
+	limiter := rate.NewLimiter(rate.Limit(rps), burst)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !limiter.Allow() {
+			http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}